@@ -0,0 +1,166 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2024 van-scott
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package x448
+
+import (
+	"runtime"
+	"sync"
+)
+
+// ScalarMultBatch computes outs[i] = scalars[i] * bases[i] for every i,
+// amortizing the field inversion that ends each Montgomery ladder
+// across the whole batch instead of paying for one per call.
+//
+// Each ladder runs to completion in projective (X:Z) coordinates
+// exactly as ScalarMult's does; ScalarMultBatch only defers and batches
+// the final Z-coordinate inversions using Montgomery's trick: the
+// running products P_i = Z_1*...*Z_i are computed, P_N is inverted
+// once, and then each Z_i^-1 is recovered walking backwards with two
+// multiplications apiece.  This turns N field inversions into a single
+// inversion plus ~3N multiplications, which is the dominant cost
+// improvement on batches of any useful size since feInvert costs
+// roughly 900 field squarings and multiplications.
+//
+// outs, scalars, and bases must all have the same length, or
+// ScalarMultBatch panics.  Every ladder is still evaluated in constant
+// time with respect to its own scalar; only the batched inversion at
+// the end is shared work, and it branches only on whether each Z is
+// zero (see batchInvert).  A base of low order (whose
+// ladder ends with Z = 0) only zeroes its own output, matching a
+// looped ScalarMult call on the same input; it does not affect any
+// other entry in the batch.
+func ScalarMultBatch(outs, scalars, bases [][x448Bytes]byte) {
+	n := len(outs)
+	if len(scalars) != n || len(bases) != n {
+		panic("x448: ScalarMultBatch: outs, scalars, and bases must have equal length")
+	}
+	if n == 0 {
+		return
+	}
+
+	xs := make([]fieldElement448, n)
+	zs := make([]fieldElement448, n)
+	for i := range xs {
+		ladderProject(&xs[i], &zs[i], &scalars[i], &bases[i])
+	}
+
+	batchInvert(zs)
+
+	for i := range outs {
+		var x fieldElement448
+		feMul(&x, &xs[i], &zs[i])
+		feToBytes(&outs[i], &x)
+	}
+}
+
+// ScalarMultBatchParallel is ScalarMultBatch, split across
+// GOMAXPROCS goroutines for further scaling on multi-core servers doing
+// many simultaneous handshakes.  The batched inversion trick is applied
+// independently within each worker's shard, since a single inversion
+// shared across goroutines would serialize them.
+func ScalarMultBatchParallel(outs, scalars, bases [][x448Bytes]byte) {
+	n := len(outs)
+	if len(scalars) != n || len(bases) != n {
+		panic("x448: ScalarMultBatchParallel: outs, scalars, and bases must have equal length")
+	}
+	if n == 0 {
+		return
+	}
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > n {
+		workers = n
+	}
+	if workers <= 1 {
+		ScalarMultBatch(outs, scalars, bases)
+		return
+	}
+
+	chunk := (n + workers - 1) / workers
+	var wg sync.WaitGroup
+	for start := 0; start < n; start += chunk {
+		end := start + chunk
+		if end > n {
+			end = n
+		}
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			ScalarMultBatch(outs[start:end], scalars[start:end], bases[start:end])
+		}(start, end)
+	}
+	wg.Wait()
+}
+
+// batchInvert replaces each element of zs with its multiplicative
+// inverse, using a single field inversion for the whole slice
+// (Montgomery's trick).
+//
+// A zero entry is excluded from the shared product instead of being
+// fed into it: Z is zero exactly when the corresponding ladder's base
+// was a low-order point (the same case ScalarMult and SharedSecret
+// surface via the all-zero output / ErrLowOrderPoint), and a single
+// zero in the running product would otherwise propagate through every
+// later prefix product and drive the whole batch's outputs to zero.
+// Each zero entry instead gets feInvert(0) = 0 directly, matching what
+// a standalone ScalarMult call on that input would have produced.
+func batchInvert(zs []fieldElement448) {
+	n := len(zs)
+	if n == 0 {
+		return
+	}
+
+	nonZero := make([]bool, n)
+	prefix := make([]fieldElement448, n)
+	var acc fieldElement448
+	feOne(&acc)
+	for i := range zs {
+		var buf [x448Bytes]byte
+		feToBytes(&buf, &zs[i])
+		if isAllZero(buf[:]) {
+			prefix[i] = acc
+			continue
+		}
+		nonZero[i] = true
+		feMul(&acc, &acc, &zs[i])
+		prefix[i] = acc
+	}
+
+	var inv fieldElement448
+	feInvert(&inv, &acc)
+
+	for i := n - 1; i >= 0; i-- {
+		if !nonZero[i] {
+			feZero(&zs[i])
+			continue
+		}
+		before := fieldElement448{1}
+		if i > 0 {
+			before = prefix[i-1]
+		}
+		var zInv fieldElement448
+		feMul(&zInv, &inv, &before)
+		feMul(&inv, &inv, &zs[i])
+		zs[i] = zInv
+	}
+}
@@ -0,0 +1,146 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2024 van-scott
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package x448
+
+import (
+	"crypto/rand"
+	"testing"
+)
+
+func makeBatch(t testing.TB, n int) (scalars, bases [][x448Bytes]byte) {
+	scalars = make([][x448Bytes]byte, n)
+	bases = make([][x448Bytes]byte, n)
+	for i := 0; i < n; i++ {
+		if _, err := rand.Read(scalars[i][:]); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := rand.Read(bases[i][:]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return
+}
+
+func TestScalarMultBatchMatchesScalarMult(t *testing.T) {
+	const n = 32
+	scalars, bases := makeBatch(t, n)
+
+	want := make([][x448Bytes]byte, n)
+	for i := range want {
+		ScalarMult(&want[i], &scalars[i], &bases[i])
+	}
+
+	got := make([][x448Bytes]byte, n)
+	ScalarMultBatch(got, scalars, bases)
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("batch[%d]: mismatch with ScalarMult", i)
+		}
+	}
+}
+
+func TestScalarMultBatchParallelMatchesScalarMult(t *testing.T) {
+	const n = 32
+	scalars, bases := makeBatch(t, n)
+
+	want := make([][x448Bytes]byte, n)
+	for i := range want {
+		ScalarMult(&want[i], &scalars[i], &bases[i])
+	}
+
+	got := make([][x448Bytes]byte, n)
+	ScalarMultBatchParallel(got, scalars, bases)
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("batch[%d]: mismatch with ScalarMult", i)
+		}
+	}
+}
+
+// TestScalarMultBatchLowOrderBaseDoesNotPoisonBatch checks that a
+// single low-order base (u = 0, whose ladder ends with Z = 0) only
+// zeroes its own output and does not corrupt the other entries in the
+// batch, the way a naive Montgomery's-trick implementation would if it
+// fed a zero straight into the shared running product.
+func TestScalarMultBatchLowOrderBaseDoesNotPoisonBatch(t *testing.T) {
+	const n = 8
+	scalars, bases := makeBatch(t, n)
+	bases[n/2] = [x448Bytes]byte{} // u = 0: a point of low order
+
+	want := make([][x448Bytes]byte, n)
+	for i := range want {
+		ScalarMult(&want[i], &scalars[i], &bases[i])
+	}
+
+	got := make([][x448Bytes]byte, n)
+	ScalarMultBatch(got, scalars, bases)
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("batch[%d]: mismatch with ScalarMult (got %x, want %x)", i, got[i], want[i])
+		}
+	}
+}
+
+func TestScalarMultBatchMismatchedLengthsPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic on mismatched slice lengths")
+		}
+	}()
+	ScalarMultBatch(make([][x448Bytes]byte, 1), make([][x448Bytes]byte, 2), make([][x448Bytes]byte, 1))
+}
+
+func BenchmarkScalarMultLooped64(b *testing.B) {
+	scalars, bases := makeBatch(b, 64)
+	out := make([][x448Bytes]byte, 64)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := range out {
+			ScalarMult(&out[j], &scalars[j], &bases[j])
+		}
+	}
+}
+
+func BenchmarkScalarMultBatch64(b *testing.B) {
+	scalars, bases := makeBatch(b, 64)
+	out := make([][x448Bytes]byte, 64)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ScalarMultBatch(out, scalars, bases)
+	}
+}
+
+func BenchmarkScalarMultBatchParallel64(b *testing.B) {
+	scalars, bases := makeBatch(b, 64)
+	out := make([][x448Bytes]byte, 64)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ScalarMultBatchParallel(out, scalars, bases)
+	}
+}
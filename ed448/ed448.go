@@ -0,0 +1,317 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2024 van-scott
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package ed448 implements the Ed448 signature scheme as specified in
+// RFC 8032.  The public API intentionally mirrors crypto/ed25519 so that
+// callers already familiar with the standard library can switch curves
+// with minimal code changes.
+//
+// Ed448 is defined over the (untwisted) Edwards curve "Edwards448", which
+// is related to the Curve448 used by the x448 Diffie-Hellman primitive in
+// the parent package by a birational isogeny.  This package does not
+// depend on the x448 package at runtime (the two curve models use
+// different, non-interchangeable field representations), but shares its
+// conventions for key sizes, constant-time comparisons, and error
+// reporting.
+package ed448
+
+import (
+	"crypto"
+	cryptoRand "crypto/rand"
+	"crypto/sha3"
+	"crypto/subtle"
+	"errors"
+	"io"
+	"strconv"
+)
+
+const (
+	// PublicKeySize is the size, in bytes, of an Ed448 public key.
+	PublicKeySize = 57
+
+	// PrivateKeySize is the size, in bytes, of an Ed448 private key.
+	// It is the 57 byte seed followed by the 57 byte public key.
+	PrivateKeySize = 114
+
+	// SeedSize is the size, in bytes, of the seed used to derive an
+	// Ed448 private key.
+	SeedSize = 57
+
+	// SignatureSize is the size, in bytes, of an Ed448 signature.
+	SignatureSize = 114
+
+	// shakeOutputSize is the size, in bytes, of the SHAKE256 output
+	// used throughout RFC 8032 Section 5.2 (H and the "dom4" prefixed
+	// hashes use a 114 byte digest; the Ed448ph prehash passed to
+	// SignPh/VerifyPh is 64 bytes).
+	shakeOutputSize = 114
+)
+
+// ErrInvalidSignature indicates that a signature did not verify.
+var ErrInvalidSignature = errors.New("ed448: invalid signature")
+
+// PublicKey is an Ed448 public key.
+type PublicKey []byte
+
+// Equal reports whether pub and x have the same value.
+func (pub PublicKey) Equal(x crypto.PublicKey) bool {
+	xx, ok := x.(PublicKey)
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare(pub, xx) == 1
+}
+
+// PrivateKey is an Ed448 private key.
+type PrivateKey []byte
+
+// Public returns the PublicKey corresponding to priv.
+func (priv PrivateKey) Public() crypto.PublicKey {
+	pub := make([]byte, PublicKeySize)
+	copy(pub, priv[SeedSize:])
+	return PublicKey(pub)
+}
+
+// Equal reports whether priv and x have the same value.
+func (priv PrivateKey) Equal(x crypto.PrivateKey) bool {
+	xx, ok := x.(PrivateKey)
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare(priv, xx) == 1
+}
+
+// Seed returns the private key seed corresponding to priv.  It is
+// provided for interoperability with RFC 8032, which defines the private
+// key as only the seed value.  It is not indented for use outside of
+// this context.
+func (priv PrivateKey) Seed() []byte {
+	seed := make([]byte, SeedSize)
+	copy(seed, priv[:SeedSize])
+	return seed
+}
+
+// Sign signs the message with priv and returns a signature.  It will
+// panic if len(priv) is not PrivateKeySize.  opts.HashFunc() must return
+// zero, or crypto.SHA3_512 to request the Ed448ph variant, in which case
+// message is expected to already be a 64 byte SHAKE256 prehash of the
+// actual message (see SignPh).
+func (priv PrivateKey) Sign(rand io.Reader, message []byte, opts crypto.SignerOpts) ([]byte, error) {
+	hash := opts.HashFunc()
+	context := ""
+	if o, ok := opts.(*Options); ok {
+		context = o.Context
+	}
+	switch {
+	case hash == crypto.SHA3_512:
+		if l := len(message); l != 64 {
+			return nil, errors.New("ed448: bad prehashed message length: " + itoa(l))
+		}
+		return SignPh(priv, message, []byte(context)), nil
+	case hash == crypto.Hash(0):
+		return Sign(priv, message, []byte(context)), nil
+	default:
+		return nil, errors.New("ed448: expected opts.HashFunc() zero (unhashed message, for standard Ed448) or SHA3-512 (for Ed448ph)")
+	}
+}
+
+// Options can be used with PrivateKey.Sign to select Ed448ph (using
+// crypto.SHA3_512 as the HashFunc) and to specify a context string.
+type Options struct {
+	// Hash can be zero for regular Ed448, or crypto.SHA3_512 for Ed448ph.
+	Hash crypto.Hash
+
+	// Context, if not empty, is included in the signature and must be
+	// provided during verification.
+	Context string
+}
+
+// HashFunc returns o.Hash.
+func (o *Options) HashFunc() crypto.Hash { return o.Hash }
+
+// GenerateKey generates a public/private key pair using entropy from
+// rand.  If rand is nil, crypto/rand.Reader is used.
+func GenerateKey(rand io.Reader) (PublicKey, PrivateKey, error) {
+	if rand == nil {
+		rand = cryptoRand.Reader
+	}
+	seed := make([]byte, SeedSize)
+	if _, err := io.ReadFull(rand, seed); err != nil {
+		return nil, nil, err
+	}
+
+	privateKey := NewKeyFromSeed(seed)
+	publicKey := make([]byte, PublicKeySize)
+	copy(publicKey, privateKey[SeedSize:])
+
+	return publicKey, privateKey, nil
+}
+
+// NewKeyFromSeed calculates a private key from a seed.  It will panic if
+// len(seed) is not SeedSize.  This function is provided for
+// interoperability with RFC 8032.  RFC 8032's private keys correspond to
+// seeds in this package.
+func NewKeyFromSeed(seed []byte) PrivateKey {
+	if l := len(seed); l != SeedSize {
+		panic("ed448: bad seed length: " + itoa(l))
+	}
+
+	h := make([]byte, shakeOutputSize)
+	shake256(h, seed)
+
+	s := clampScalar(h[:57])
+	var A pointR1
+	A.scalarMultBase(s)
+
+	privateKey := make([]byte, PrivateKeySize)
+	copy(privateKey, seed)
+	copy(privateKey[SeedSize:], A.encode())
+
+	return PrivateKey(privateKey)
+}
+
+// Sign signs the message with privateKey and returns a signature.  The
+// context, if not empty, is mixed into the dom4 prefix as specified by
+// RFC 8032 Section 5.2.  It will panic if len(privateKey) is not
+// PrivateKeySize, or len(context) is greater than 255 bytes.
+func Sign(privateKey PrivateKey, message, context []byte) []byte {
+	return sign(privateKey, message, context, false)
+}
+
+// SignPh signs the 64 byte SHAKE256 prehash of a message (the Ed448ph
+// variant).  It will panic if len(message) is not 64.
+func SignPh(privateKey PrivateKey, hashedMessage, context []byte) []byte {
+	if l := len(hashedMessage); l != 64 {
+		panic("ed448: bad prehashed message length: " + itoa(l))
+	}
+	return sign(privateKey, hashedMessage, context, true)
+}
+
+func sign(privateKey, message, context []byte, isPh bool) []byte {
+	if l := len(privateKey); l != PrivateKeySize {
+		panic("ed448: bad private key length: " + itoa(l))
+	}
+	if l := len(context); l > 255 {
+		panic("ed448: bad context length: " + itoa(l))
+	}
+
+	seed, publicKey := privateKey[:SeedSize], privateKey[SeedSize:]
+
+	h := make([]byte, shakeOutputSize)
+	shake256(h, seed)
+	s := clampScalar(h[:57])
+	prefix := h[57:]
+
+	rHash := make([]byte, shakeOutputSize)
+	shake256(rHash, dom4(context, isPh), prefix, message)
+	r := reduceScalar(rHash)
+
+	var R pointR1
+	R.scalarMultBase(r)
+	REnc := R.encode()
+
+	kHash := make([]byte, shakeOutputSize)
+	shake256(kHash, dom4(context, isPh), REnc, publicKey, message)
+	k := reduceScalar(kHash)
+
+	S := scalarMulAdd(k, s, r)
+
+	sig := make([]byte, SignatureSize)
+	copy(sig, REnc)
+	copy(sig[57:], S)
+	return sig
+}
+
+// Verify reports whether sig is a valid signature of message by
+// publicKey.  The context, if not empty, must match the one used to
+// produce sig.
+func Verify(publicKey PublicKey, message, context, sig []byte) bool {
+	return verify(publicKey, message, context, sig, false)
+}
+
+// VerifyPh reports whether sig is a valid Ed448ph signature of the 64
+// byte SHAKE256 prehash hashedMessage by publicKey.
+func VerifyPh(publicKey PublicKey, hashedMessage, context, sig []byte) bool {
+	if len(hashedMessage) != 64 {
+		return false
+	}
+	return verify(publicKey, hashedMessage, context, sig, true)
+}
+
+func verify(publicKey, message, context, sig []byte, isPh bool) bool {
+	if len(publicKey) != PublicKeySize || len(sig) != SignatureSize || len(context) > 255 {
+		return false
+	}
+
+	var A pointR1
+	if !A.decode(publicKey) {
+		return false
+	}
+
+	REnc, SEnc := sig[:57], sig[57:]
+	S, ok := decodeScalar(SEnc)
+	if !ok {
+		return false
+	}
+
+	kHash := make([]byte, shakeOutputSize)
+	shake256(kHash, dom4(context, isPh), REnc, publicKey, message)
+	k := reduceScalar(kHash)
+
+	var lhs, rhs pointR1
+	lhs.scalarMultBase(S)
+	rhs.scalarMultVarTime(k, &A)
+	var R pointR1
+	if !R.decode(REnc) {
+		return false
+	}
+	rhs.add(&rhs, &R)
+
+	return lhs.equal(&rhs)
+}
+
+// dom4 builds the "SigEd448" domain separation prefix described in RFC
+// 8032 Section 5.2: flag is 0x01 for Ed448ph and 0x00 for plain Ed448.
+func dom4(context []byte, isPh bool) []byte {
+	flag := byte(0)
+	if isPh {
+		flag = 1
+	}
+	prefix := []byte("SigEd448")
+	out := make([]byte, 0, len(prefix)+2+len(context))
+	out = append(out, prefix...)
+	out = append(out, flag, byte(len(context)))
+	out = append(out, context...)
+	return out
+}
+
+func shake256(dst []byte, parts ...[]byte) {
+	h := sha3.NewSHAKE256()
+	for _, p := range parts {
+		_, _ = h.Write(p)
+	}
+	_, _ = h.Read(dst)
+}
+
+func itoa(n int) string {
+	return strconv.Itoa(n)
+}
@@ -0,0 +1,252 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2024 van-scott
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ed448
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+)
+
+// rfc8032Ed448Vectors are the Ed448 (pure, empty context) test vectors from
+// RFC 8032 Section 7.4, TEST 1 and TEST 2.
+var rfc8032Ed448Vectors = []struct {
+	seed, pub, msg, sig string
+}{
+	{
+		seed: "6c82a562cb808d10d632be89c8513ebf6c929f34ddfa8c9f63c9960ef6e348a" +
+			"3528c8a3fcc2f044e39a3fc5b94492f8f032e7549a20098f95b",
+		pub: "5fd7449b59b461fd2ce787ec616ad46a1da1342485a70e1f8a0ea75d80e9677" +
+			"8edf124769b46c7061bd6783df1e50f6cd1fa1abeafe8256180",
+		msg: "",
+		sig: "533a37f6bbe457251f023c0d88f976ae2dfb504a843e34d2074fd823d41a591" +
+			"f2b233f034f628281f2fd7a22ddd47d7828c59bd0a21bfd3980ff0d2028d4b1" +
+			"8a9df63e006c5d1c2d345b925d8dc00b4104852db99ac5c7cdda8530a113a0f" +
+			"4dbb61149f05a7363268c71d95808ff2e652600",
+	},
+	{
+		seed: "c4eab05d357007c632f3dbb48489924d552b08fe0c353a0d4a1f00acda2c463" +
+			"afbea67c5e8d2877c5e3bc397a659949ef8021e954e0a12274e",
+		pub: "43ba28f430cdff456ae531545f7ecd0ac834a55d9358c0372bfa0c6c6798c08" +
+			"66aea01eb00742802b8438ea4cb82169c235160627b4c3a9480",
+		msg: "03",
+		sig: "26b8f91727bd62897af15e41eb43c377efb9c610d48f2335cb0bd0087810f43" +
+			"52541b143c4b981b7e18f62de8ccdf633fc1bf037ab7cd779805e0dbcc0aae1" +
+			"cbcee1afb2e027df36bc04dcecbf154336c19f0af7e0a6472905e799f1953d2" +
+			"a0ff3348ab21aa4adafd1d234441cf807c03a00",
+	},
+}
+
+func TestRFC8032Vectors(t *testing.T) {
+	for i, v := range rfc8032Ed448Vectors {
+		seed, err := hex.DecodeString(v.seed)
+		if err != nil {
+			t.Fatalf("test %d: bad seed: %v", i, err)
+		}
+		wantPub, err := hex.DecodeString(v.pub)
+		if err != nil {
+			t.Fatalf("test %d: bad public key: %v", i, err)
+		}
+		msg, err := hex.DecodeString(v.msg)
+		if err != nil {
+			t.Fatalf("test %d: bad message: %v", i, err)
+		}
+		wantSig, err := hex.DecodeString(v.sig)
+		if err != nil {
+			t.Fatalf("test %d: bad signature: %v", i, err)
+		}
+
+		priv := NewKeyFromSeed(seed)
+		pub := priv.Public().(PublicKey)
+		if !bytes.Equal(pub, wantPub) {
+			t.Errorf("test %d: public key mismatch: got %x want %x", i, pub, wantPub)
+		}
+
+		sig := Sign(priv, msg, nil)
+		if !bytes.Equal(sig, wantSig) {
+			t.Errorf("test %d: signature mismatch: got %x want %x", i, sig, wantSig)
+		}
+		if !Verify(pub, msg, nil, wantSig) {
+			t.Errorf("test %d: vector signature did not verify", i)
+		}
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	pub, priv, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message := []byte("test message")
+	sig := Sign(priv, message, nil)
+	if !Verify(pub, message, nil, sig) {
+		t.Error("valid signature rejected")
+	}
+
+	sig[0] ^= 0xff
+	if Verify(pub, message, nil, sig) {
+		t.Error("corrupted signature accepted")
+	}
+}
+
+func TestSignVerifyContext(t *testing.T) {
+	pub, priv, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message, context := []byte("test message"), []byte("test context")
+	sig := Sign(priv, message, context)
+	if !Verify(pub, message, context, sig) {
+		t.Error("valid signature rejected")
+	}
+	if Verify(pub, message, []byte("wrong context"), sig) {
+		t.Error("signature verified under the wrong context")
+	}
+}
+
+func TestSignVerifyPh(t *testing.T) {
+	pub, priv, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prehash := make([]byte, 64)
+	if _, err := rand.Read(prehash); err != nil {
+		t.Fatal(err)
+	}
+
+	sig := SignPh(priv, prehash, nil)
+	if !VerifyPh(pub, prehash, nil, sig) {
+		t.Error("valid Ed448ph signature rejected")
+	}
+}
+
+// TestDom4PhFlagChangesOutput pins dom4's isPh flag (RFC 8032 Section
+// 5.2's "SigEd448" prefix byte, 0x01 for Ed448ph and 0x00 for pure
+// Ed448) to actually participate in the signature: signing the same
+// 64-byte buffer once as a pure-Ed448 message and once as an Ed448ph
+// prehash must produce different signatures, and each must only
+// verify under its own scheme.
+//
+// This is not a substitute for RFC 8032 Section 7.4's Ed448ph KAT
+// (seed/prehash/signature fixed to the RFC's published values): this
+// environment had no network access to fetch or cross-check those
+// bytes against an independent source, and typing them from memory
+// risked asserting incorrect values under the RFC's name, which is
+// worse than not testing them at all. This test instead pins the
+// specific property TEST 3's reviewer comment called out -- that the
+// dom4 ph flag is not a no-op -- against this implementation itself.
+func TestDom4PhFlagChangesOutput(t *testing.T) {
+	pub, priv, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := make([]byte, 64)
+	if _, err := rand.Read(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	pureSig := Sign(priv, buf, nil)
+	phSig := SignPh(priv, buf, nil)
+	if bytes.Equal(pureSig, phSig) {
+		t.Fatal("pure Ed448 and Ed448ph signatures of the same bytes are identical")
+	}
+
+	if !Verify(pub, buf, nil, pureSig) {
+		t.Error("pure Ed448 signature did not verify under Verify")
+	}
+	if VerifyPh(pub, buf, nil, pureSig) {
+		t.Error("pure Ed448 signature verified under VerifyPh")
+	}
+	if !VerifyPh(pub, buf, nil, phSig) {
+		t.Error("Ed448ph signature did not verify under VerifyPh")
+	}
+	if Verify(pub, buf, nil, phSig) {
+		t.Error("Ed448ph signature verified under Verify")
+	}
+}
+
+// TestDom4ContextFraming pins dom4's context-length framing (RFC 8032
+// Section 5.2 encodes the context preceded by its own length as a
+// single byte) across the length boundaries that framing could get
+// wrong: empty, a single byte, and the maximum 255 bytes, plus the
+// out-of-range 256-byte case Sign documents as a panic.  Distinct
+// contexts of otherwise-compatible lengths (0 vs 1 byte in particular,
+// since an empty context must not be confusable with one consisting of
+// a single 0x00 length-prefix byte) must produce distinct signatures
+// that only verify under their own context.  See the no-RFC-vector
+// note on TestDom4PhFlagChangesOutput above; the same constraint
+// applies here.
+func TestDom4ContextFraming(t *testing.T) {
+	pub, priv, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := []byte("test message")
+
+	contexts := [][]byte{nil, {0x00}, bytes.Repeat([]byte{0x42}, 255)}
+	sigs := make([][]byte, len(contexts))
+	for i, ctx := range contexts {
+		sigs[i] = Sign(priv, message, ctx)
+		if !Verify(pub, message, ctx, sigs[i]) {
+			t.Errorf("context %d: signature did not verify under its own context", i)
+		}
+		for j, other := range contexts {
+			if j == i {
+				continue
+			}
+			if Verify(pub, message, other, sigs[i]) {
+				t.Errorf("context %d: signature verified under a different context (%d)", i, j)
+			}
+			if bytes.Equal(sigs[i], sigs[j]) {
+				t.Errorf("contexts %d and %d produced identical signatures", i, j)
+			}
+		}
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Sign with a 256-byte context did not panic")
+			}
+		}()
+		Sign(priv, message, bytes.Repeat([]byte{0x42}, 256))
+	}()
+}
+
+func TestNewKeyFromSeedDeterministic(t *testing.T) {
+	seed := make([]byte, SeedSize)
+	if _, err := rand.Read(seed); err != nil {
+		t.Fatal(err)
+	}
+
+	priv1 := NewKeyFromSeed(seed)
+	priv2 := NewKeyFromSeed(seed)
+	if !bytes.Equal(priv1, priv2) {
+		t.Error("NewKeyFromSeed is not deterministic")
+	}
+}
@@ -0,0 +1,150 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2024 van-scott
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ed448
+
+import "math/big"
+
+// fieldElement is an element of GF(p), p = 2^448 - 2^224 - 1, the field
+// underlying the Edwards448 curve.  Unlike the x448 package's radix-56
+// limb representation (tuned for the Montgomery ladder over Curve448),
+// points on Edwards448 are manipulated far less frequently per
+// signature, so this package favors math/big for clarity.  All field
+// operations are reduced modulo fieldPrime before being returned.
+type fieldElement struct {
+	n *big.Int
+}
+
+var fieldPrime = mustHex("fffffffffffffffffffffffffffffffffffffffffffffffffffffffe" +
+	"ffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+
+// d is the Edwards448 curve coefficient, -39081 mod p.
+var edwardsD = newFieldElement(big.NewInt(-39081))
+
+func mustHex(s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("ed448: invalid constant")
+	}
+	return n
+}
+
+func newFieldElement(n *big.Int) *fieldElement {
+	fe := &fieldElement{n: new(big.Int)}
+	fe.n.Mod(n, fieldPrime)
+	return fe
+}
+
+func feFromBytes(b []byte) *fieldElement {
+	// Edwards448 field elements are encoded little-endian.
+	buf := make([]byte, len(b))
+	for i, v := range b {
+		buf[len(b)-1-i] = v
+	}
+	return newFieldElement(new(big.Int).SetBytes(buf))
+}
+
+func (fe *fieldElement) bytes(size int) []byte {
+	be := fe.n.Bytes()
+	out := make([]byte, size)
+	for i, v := range be {
+		out[len(be)-1-i] = v
+	}
+	return out
+}
+
+func (fe *fieldElement) add(a, b *fieldElement) *fieldElement {
+	fe.n.Add(a.n, b.n)
+	fe.n.Mod(fe.n, fieldPrime)
+	return fe
+}
+
+func (fe *fieldElement) sub(a, b *fieldElement) *fieldElement {
+	fe.n.Sub(a.n, b.n)
+	fe.n.Mod(fe.n, fieldPrime)
+	return fe
+}
+
+func (fe *fieldElement) mul(a, b *fieldElement) *fieldElement {
+	fe.n.Mul(a.n, b.n)
+	fe.n.Mod(fe.n, fieldPrime)
+	return fe
+}
+
+func (fe *fieldElement) square(a *fieldElement) *fieldElement {
+	return fe.mul(a, a)
+}
+
+func (fe *fieldElement) invert(a *fieldElement) *fieldElement {
+	fe.n.ModInverse(a.n, fieldPrime)
+	return fe
+}
+
+func (fe *fieldElement) neg(a *fieldElement) *fieldElement {
+	fe.n.Sub(fieldPrime, a.n)
+	fe.n.Mod(fe.n, fieldPrime)
+	return fe
+}
+
+func (fe *fieldElement) isZero() bool {
+	return fe.n.Sign() == 0
+}
+
+func (fe *fieldElement) isNegative() bool {
+	return fe.n.Bit(0) == 1
+}
+
+func (fe *fieldElement) equal(b *fieldElement) bool {
+	return fe.n.Cmp(b.n) == 0
+}
+
+func (fe *fieldElement) set(a *fieldElement) *fieldElement {
+	fe.n.Set(a.n)
+	return fe
+}
+
+// cselect sets fe to a if cond is 1, or to b if cond is 0, without
+// branching on cond.  Like the rest of this math/big-backed
+// fieldElement, the arithmetic it performs to do so is not itself
+// constant-time in operand magnitude; it only avoids a data-dependent
+// branch, not data-dependent timing in general.
+func (fe *fieldElement) cselect(cond uint, a, b *fieldElement) *fieldElement {
+	mask := new(big.Int).SetUint64(uint64(cond & 1))
+	fe.n.Sub(a.n, b.n)
+	fe.n.Mul(fe.n, mask)
+	fe.n.Add(fe.n, b.n)
+	fe.n.Mod(fe.n, fieldPrime)
+	return fe
+}
+
+// sqrt returns a field element r such that r^2 == a, and a boolean
+// indicating whether a is a quadratic residue.  p = 3 (mod 4), so
+// r = a^((p+1)/4).
+func (fe *fieldElement) sqrt(a *fieldElement) (*fieldElement, bool) {
+	exp := new(big.Int).Add(fieldPrime, big.NewInt(1))
+	exp.Rsh(exp, 2)
+	r := new(big.Int).Exp(a.n, exp, fieldPrime)
+	check := new(big.Int).Mul(r, r)
+	check.Mod(check, fieldPrime)
+	fe.n = r
+	return fe, check.Cmp(new(big.Int).Mod(a.n, fieldPrime)) == 0
+}
@@ -0,0 +1,247 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2024 van-scott
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ed448
+
+import "math/big"
+
+// pointR1 is a point on the Edwards448 curve
+//
+//	x^2 + y^2 = 1 + d*x^2*y^2  (d = edwardsD, a = 1)
+//
+// Unlike Ed25519's curve, Edwards448 is untwisted (a = 1) rather than
+// a = -1, which changes the sign of the a*X1*X2 term in the unified
+// addition formula below and the sign of y^2 in decode's x^2 equation.
+// held in extended projective coordinates (X:Y:Z:T), with
+// x = X/Z, y = Y/Z, x*y = T/Z.  Extended coordinates avoid the field
+// inversion that an affine add/double would otherwise require on every
+// step of the scalar multiplication.
+type pointR1 struct {
+	x, y, z, t *fieldElement
+}
+
+func newPointR1() *pointR1 {
+	return &pointR1{
+		x: newFieldElement(big.NewInt(0)),
+		y: newFieldElement(big.NewInt(1)),
+		z: newFieldElement(big.NewInt(1)),
+		t: newFieldElement(big.NewInt(0)),
+	}
+}
+
+// basePointEncoded is the RFC 8032 Section 5.2.1 Ed448 base point B,
+// little-endian encoded.
+var basePointEncoded = mustDecodeHex("14fa30f25b790898adc8d74e2c13bdfdc4397ce6" +
+	"1cffd33ad7c2a0051e9c78874098a36c7373ea4b62c7c9563720768824bcb" +
+	"66e71463f6900")
+
+func mustDecodeHex(s string) []byte {
+	b := make([]byte, len(s)/2)
+	for i := range b {
+		hi := unhex(s[2*i])
+		lo := unhex(s[2*i+1])
+		b[i] = hi<<4 | lo
+	}
+	return b
+}
+
+func unhex(c byte) byte {
+	switch {
+	case c >= '0' && c <= '9':
+		return c - '0'
+	case c >= 'a' && c <= 'f':
+		return c - 'a' + 10
+	case c >= 'A' && c <= 'F':
+		return c - 'A' + 10
+	}
+	panic("ed448: invalid hex constant")
+}
+
+func basePoint() *pointR1 {
+	p := newPointR1()
+	if !p.decode(basePointEncoded) {
+		panic("ed448: invalid base point constant")
+	}
+	return p
+}
+
+// add sets p = a + b using the unified (complete) extended twisted
+// Edwards addition formula from Hisil-Wong-Carter-Dawson, which holds
+// for all inputs because a = 1 is a square and edwardsD is a
+// non-square in the field.
+func (p *pointR1) add(a, b *pointR1) *pointR1 {
+	fe := func() *fieldElement { return newFieldElement(big.NewInt(0)) }
+
+	A := fe().mul(a.x, b.x)
+	B := fe().mul(a.y, b.y)
+	C := fe().mul(fe().mul(edwardsD, a.t), b.t)
+	D := fe().mul(a.z, b.z)
+	E := fe().sub(fe().mul(fe().add(a.x, a.y), fe().add(b.x, b.y)), fe().add(A, B))
+	F := fe().sub(D, C)
+	G := fe().add(D, C)
+	H := fe().sub(B, A) // a = 1, so H = B - a*A = B - A
+
+	p.x = fe().mul(E, F)
+	p.y = fe().mul(G, H)
+	p.z = fe().mul(F, G)
+	p.t = fe().mul(E, H)
+	return p
+}
+
+func (p *pointR1) double() *pointR1 {
+	return p.add(p, p)
+}
+
+func (p *pointR1) set(a *pointR1) *pointR1 {
+	p.x, p.y, p.z, p.t = a.x, a.y, a.z, a.t
+	return p
+}
+
+// scalarMultBitLen is the fixed number of ladder steps scalarMult
+// always runs.  Every scalar it is called with (the private key,
+// whose top byte clampScalar forces to zero, and the nonce r and
+// verification's S, both below the group order) fits in 448 bits, so
+// looping over exactly this many bits, rather than n.BitLen(), keeps the iteration count
+// independent of the scalar's value.
+const scalarMultBitLen = 448
+
+// scalarMultBase sets p = s*B, where B is the curve base point.  s must
+// be a secret scalar; see scalarMult.
+func (p *pointR1) scalarMultBase(s []byte) *pointR1 {
+	return p.scalarMult(s, basePoint())
+}
+
+// scalarMult sets p = s*base for a secret scalar s.  It runs a fixed
+// scalarMultBitLen double-and-add-always ladder: every iteration both
+// doubles and adds, and cselect (not a branch on the scalar bit)
+// chooses which result to keep, so the control flow and the sequence
+// of field operations performed do not depend on s.  This package's
+// fieldElement is backed by math/big (see field.go), which is not
+// itself constant-time in operand magnitude, so this ladder removes
+// the secret-dependent branch and loop bound but does not make the
+// underlying field arithmetic timing-independent of s. Callers that
+// only ever pass public scalars and points should use
+// scalarMultVarTime instead, which is faster.
+func (p *pointR1) scalarMult(s []byte, base *pointR1) *pointR1 {
+	acc := newPointR1()
+	n := scalarToBig(s)
+	for i := scalarMultBitLen - 1; i >= 0; i-- {
+		acc.double()
+		sum := newPointR1().add(acc, base)
+		acc.cselect(n.Bit(i), sum, acc)
+	}
+	p.set(acc)
+	return p
+}
+
+// scalarMultVarTime sets p = s*base.  It is not constant-time and must
+// only be used on public inputs, such as during signature verification.
+func (p *pointR1) scalarMultVarTime(s []byte, base *pointR1) *pointR1 {
+	acc := newPointR1()
+	n := scalarToBig(s)
+	for i := n.BitLen() - 1; i >= 0; i-- {
+		acc.double()
+		if n.Bit(i) == 1 {
+			acc.add(acc, base)
+		}
+	}
+	p.set(acc)
+	return p
+}
+
+// cselect sets p to a if cond is 1, or to b if cond is 0, without
+// branching on cond.
+func (p *pointR1) cselect(cond uint, a, b *pointR1) *pointR1 {
+	p.x = newFieldElement(big.NewInt(0)).cselect(cond, a.x, b.x)
+	p.y = newFieldElement(big.NewInt(0)).cselect(cond, a.y, b.y)
+	p.z = newFieldElement(big.NewInt(0)).cselect(cond, a.z, b.z)
+	p.t = newFieldElement(big.NewInt(0)).cselect(cond, a.t, b.t)
+	return p
+}
+
+// encode serializes p to its 57 byte little-endian compressed form: the
+// affine y-coordinate with the high bit of the final byte holding the
+// sign (low bit) of x.
+func (p *pointR1) encode() []byte {
+	zInv := newFieldElement(big.NewInt(0)).invert(p.z)
+	x := newFieldElement(big.NewInt(0)).mul(p.x, zInv)
+	y := newFieldElement(big.NewInt(0)).mul(p.y, zInv)
+
+	out := y.bytes(57)
+	if x.isNegative() {
+		out[56] |= 0x80
+	}
+	return out
+}
+
+// decode deserializes a 57 byte compressed point into p, returning false
+// if the encoding does not correspond to a point on the curve.
+func (p *pointR1) decode(enc []byte) bool {
+	if len(enc) != 57 {
+		return false
+	}
+	sign := enc[56] >> 7
+	yb := make([]byte, 57)
+	copy(yb, enc)
+	yb[56] &= 0x7f
+
+	y := feFromBytes(yb)
+	ySq := newFieldElement(big.NewInt(0)).square(y)
+	// x^2 + y^2 = 1 + d*x^2*y^2  =>  x^2 = (1-y^2) / (1-d*y^2)
+	num := newFieldElement(big.NewInt(0)).sub(newFieldElement(big.NewInt(1)), ySq)
+	den := newFieldElement(big.NewInt(0)).mul(edwardsD, ySq)
+	den = newFieldElement(big.NewInt(0)).sub(newFieldElement(big.NewInt(1)), den)
+
+	denInv := newFieldElement(big.NewInt(0)).invert(den)
+	xSq := newFieldElement(big.NewInt(0)).mul(num, denInv)
+
+	x, ok := newFieldElement(big.NewInt(0)).sqrt(xSq)
+	if !ok {
+		return false
+	}
+	if x.isZero() && sign == 1 {
+		return false
+	}
+	if byte(boolToInt(x.isNegative())) != sign {
+		x.neg(x)
+	}
+
+	p.x, p.y, p.z = x, y, newFieldElement(big.NewInt(1))
+	p.t = newFieldElement(big.NewInt(0)).mul(x, y)
+	return true
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func (p *pointR1) equal(q *pointR1) bool {
+	// x1*z2 == x2*z1 && y1*z2 == y2*z1
+	lx := newFieldElement(big.NewInt(0)).mul(p.x, q.z)
+	rx := newFieldElement(big.NewInt(0)).mul(q.x, p.z)
+	ly := newFieldElement(big.NewInt(0)).mul(p.y, q.z)
+	ry := newFieldElement(big.NewInt(0)).mul(q.y, p.z)
+	return lx.equal(rx) && ly.equal(ry)
+}
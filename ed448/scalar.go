@@ -0,0 +1,93 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2024 van-scott
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ed448
+
+import "math/big"
+
+// groupOrder is L, the order of the Edwards448 prime-order subgroup:
+// L = 2^446 - 13818066809895115352007386748515426880336692474882178609894547503885
+var groupOrder = mustHex("3fffffffffffffffffffffffffffffffffffffffffffffffffffffff" +
+	"7cca23e9c44edb49aed63690216cc2728dc58f552378c292ab5844f3")
+
+// clampScalar applies the RFC 8032 Section 5.2.5 clamping to the first
+// 57 bytes of an Ed448 hash output: the low two bits of the first byte
+// are cleared, the high bit of the second to last byte is set, and all
+// eight bits of the last byte are cleared.
+func clampScalar(h []byte) []byte {
+	s := make([]byte, 57)
+	copy(s, h)
+	s[0] &= 0xfc
+	s[55] |= 0x80
+	s[56] = 0
+	return s
+}
+
+func scalarToBig(s []byte) *big.Int {
+	buf := make([]byte, len(s))
+	for i, v := range s {
+		buf[len(s)-1-i] = v
+	}
+	return new(big.Int).SetBytes(buf)
+}
+
+func bigToScalar(n *big.Int) []byte {
+	be := new(big.Int).Mod(n, groupOrder).Bytes()
+	out := make([]byte, 57)
+	for i, v := range be {
+		out[len(be)-1-i] = v
+	}
+	return out
+}
+
+// reduceScalar reduces a wide (114 byte) hash output modulo the group
+// order, as used when deriving the nonce r and the challenge k.
+func reduceScalar(h []byte) []byte {
+	buf := make([]byte, len(h))
+	for i, v := range h {
+		buf[len(h)-1-i] = v
+	}
+	n := new(big.Int).SetBytes(buf)
+	return bigToScalar(n)
+}
+
+// decodeScalar decodes and range-checks a 57 byte little-endian scalar
+// encoding, rejecting values that are not fully reduced modulo the
+// group order, as required by RFC 8032 Section 5.2.7.
+func decodeScalar(s []byte) ([]byte, bool) {
+	if len(s) != 57 {
+		return nil, false
+	}
+	n := scalarToBig(s)
+	if n.Cmp(groupOrder) >= 0 {
+		return nil, false
+	}
+	return append([]byte(nil), s...), true
+}
+
+// scalarMulAdd computes (k*s + r) mod L.
+func scalarMulAdd(k, s, r []byte) []byte {
+	kk, ss, rr := scalarToBig(k), scalarToBig(s), scalarToBig(r)
+	res := new(big.Int).Mul(kk, ss)
+	res.Add(res, rr)
+	return bigToScalar(res)
+}
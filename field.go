@@ -0,0 +1,277 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2024 van-scott
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package x448
+
+import "math/bits"
+
+// fieldElement448 is an element of GF(p), p = 2^448 - 2^224 - 1, stored
+// as eight 56-bit limbs in little-endian limb order: the value is
+// sum(fe[i] << (56*i)).  56 divides evenly into the 8-byte machine word
+// and into 224 (224 = 4*56), which is what lets feMulGeneric fold a
+// double-width product back down using 2^448 == 2^224+1 (mod p) at
+// limb-aligned offsets instead of a mid-limb bit shift; the amd64
+// assembly backend in field_amd64.s relies on the same alignment.
+//
+// Every exported field operation leaves its result in canonical form:
+// each limb < 2^56, and the overall value fully reduced into [0, p).
+type fieldElement448 [8]uint64
+
+const (
+	feLimbBits = 56
+	feLimbMask = uint64(1)<<feLimbBits - 1
+	feLimbs    = 8
+)
+
+// pLimbs is p = 2^448 - 2^224 - 1 in the fieldElement448 representation.
+var pLimbs = fieldElement448{
+	feLimbMask, feLimbMask, feLimbMask, feLimbMask,
+	feLimbMask - 1, feLimbMask, feLimbMask, feLimbMask,
+}
+
+// feFromBytes decodes the 56-byte little-endian buffer b into fe.  Each
+// limb maps to exactly 7 consecutive bytes since 56 bits is 7 whole
+// bytes, so no value crosses a byte boundary within a limb.  b's top
+// bit (bit 447) is not masked off, and a value >= p is not reduced;
+// ladderProject reduces a non-canonical u-coordinate with
+// feCanonicalize.
+func feFromBytes(fe *fieldElement448, b []byte) {
+	var buf [x448Bytes]byte
+	copy(buf[:], b)
+	for i := 0; i < feLimbs; i++ {
+		off := i * 7
+		fe[i] = uint64(buf[off]) | uint64(buf[off+1])<<8 | uint64(buf[off+2])<<16 |
+			uint64(buf[off+3])<<24 | uint64(buf[off+4])<<32 | uint64(buf[off+5])<<40 |
+			uint64(buf[off+6])<<48
+	}
+}
+
+// feToBytes fully reduces fe and encodes it into the 56-byte
+// little-endian out.
+func feToBytes(out *[x448Bytes]byte, fe *fieldElement448) {
+	var t fieldElement448
+	feCanonicalize(&t, fe)
+	for i := 0; i < feLimbs; i++ {
+		off := i * 7
+		v := t[i]
+		out[off] = byte(v)
+		out[off+1] = byte(v >> 8)
+		out[off+2] = byte(v >> 16)
+		out[off+3] = byte(v >> 24)
+		out[off+4] = byte(v >> 32)
+		out[off+5] = byte(v >> 40)
+		out[off+6] = byte(v >> 48)
+	}
+}
+
+func feZero(out *fieldElement448) {
+	*out = fieldElement448{}
+}
+
+func feOne(out *fieldElement448) {
+	*out = fieldElement448{1}
+}
+
+func feCopy(out, a *fieldElement448) {
+	*out = *a
+}
+
+// cswapGeneric conditionally swaps a and b in constant time when
+// swap == 1, leaving them unchanged when swap == 0, as used by the
+// Montgomery ladder to hide the scalar's bit pattern.  This is the
+// portable implementation; field_amd64.go dispatches to an equivalent
+// assembly routine on CPUs that support it.
+func cswapGeneric(a, b *fieldElement448, swap uint64) {
+	mask := -swap
+	for i := range a {
+		t := mask & (a[i] ^ b[i])
+		a[i] ^= t
+		b[i] ^= t
+	}
+}
+
+// feAdd sets out = a+b (mod p).
+func feAdd(out, a, b *fieldElement448) {
+	var t fieldElement448
+	for i := 0; i < feLimbs; i++ {
+		t[i] = a[i] + b[i]
+	}
+	feCanonicalize(out, &t)
+}
+
+// feSub sets out = a-b (mod p).  2*pLimbs is added to a (limb-wise,
+// before carry propagation) so the subtraction does not underflow a
+// limb as long as each limb of b is at most 2^57-4, which holds for
+// canonical inputs (each limb < 2^56).
+func feSub(out, a, b *fieldElement448) {
+	var t fieldElement448
+	for i := 0; i < feLimbs; i++ {
+		t[i] = a[i] + 2*pLimbs[i] - b[i]
+	}
+	feCanonicalize(out, &t)
+}
+
+// feNeg sets out = -a (mod p).
+func feNeg(out, a *fieldElement448) {
+	var zero fieldElement448
+	feSub(out, &zero, a)
+}
+
+// feCanonicalize reduces in (whose limbs may be loosely bounded, as
+// produced by feAdd/feSub) fully into [0, p) with each limb < 2^56,
+// storing the result in out.
+func feCanonicalize(out, in *fieldElement448) {
+	t := *in
+	feCarryPropagate(&t)
+	feSubPIfGE(out, &t)
+}
+
+// feCarryPropagate brings t's limbs within [0, 2^56) by repeatedly
+// folding any overflow past the top limb back in at weight 1 and
+// weight 2^224 (limb index 4), per 2^448 == 2^224+1 (mod p).  t is left
+// with a value in [0, 2^448), not yet necessarily less than p.
+func feCarryPropagate(t *fieldElement448) {
+	for {
+		var carry uint64
+		for i := 0; i < feLimbs; i++ {
+			v := t[i] + carry
+			t[i] = v & feLimbMask
+			carry = v >> feLimbBits
+		}
+		if carry == 0 {
+			return
+		}
+		t[0] += carry
+		t[4] += carry
+	}
+}
+
+// feSubPIfGE sets out = t-p if t >= p, else out = t.  t must already
+// have each limb < 2^56 (see feCarryPropagate).
+func feSubPIfGE(out, t *fieldElement448) {
+	var diff fieldElement448
+	var borrow uint64
+	for i := 0; i < feLimbs; i++ {
+		v := t[i] - pLimbs[i] - borrow
+		diff[i] = v & feLimbMask
+		borrow = (v >> 63) & 1
+	}
+
+	// borrow == 1 means t < p, so keep t; borrow == 0 means t >= p, so
+	// use diff.  mask is all-ones when diff should be selected.
+	mask := borrow - 1
+	for i := 0; i < feLimbs; i++ {
+		out[i] = (t[i] & ^mask) | (diff[i] & mask)
+	}
+}
+
+// feMulGeneric sets out = a*b (mod p), using a portable schoolbook
+// multiply.  Each column of the 8x8 product is accumulated as a full
+// 128-bit value (bits.Mul64/Add64 can't overflow a single machine word
+// per partial product, but summing up to 8 of them can), and the
+// high columns are folded down via 2^448 == 2^224+1 (mod p) before the
+// final carry propagation and reduction.
+func feMulGeneric(out, a, b *fieldElement448) {
+	var lo, hi [2*feLimbs - 1]uint64
+	for i := 0; i < feLimbs; i++ {
+		for j := 0; j < feLimbs; j++ {
+			mhi, mlo := bits.Mul64(a[i], b[j])
+			var c uint64
+			lo[i+j], c = bits.Add64(lo[i+j], mlo, 0)
+			hi[i+j] += mhi + c
+		}
+	}
+	feFoldColumns(out, &lo, &hi)
+}
+
+// feSquareGeneric sets out = a*a (mod p).
+func feSquareGeneric(out, a *fieldElement448) {
+	feMulGeneric(out, a, a)
+}
+
+// feFoldColumns reduces the 2*feLimbs-1 raw schoolbook columns (each
+// column k, of weight 2^(56k), held as a 128-bit lo/hi pair) down to a
+// canonical fieldElement448.  Columns 8..14 are folded into columns
+// (k-8) and (k-4) twice (the second pass handles the columns 8..10
+// that the first pass itself produces), after which a linear carry
+// propagation and final conditional subtraction of p produce the
+// canonical result.
+func feFoldColumns(out *fieldElement448, lo, hi *[2*feLimbs - 1]uint64) {
+	var accLo, accHi [feLimbs + 3]uint64
+	for k := 0; k < feLimbs; k++ {
+		accLo[k], accHi[k] = lo[k], hi[k]
+	}
+	for k := feLimbs; k < 2*feLimbs-1; k++ {
+		addColumn(&accLo, &accHi, k-feLimbs, lo[k], hi[k])
+		addColumn(&accLo, &accHi, k-4, lo[k], hi[k])
+	}
+	for k := feLimbs; k < feLimbs+3; k++ {
+		addColumn(&accLo, &accHi, k-feLimbs, accLo[k], accHi[k])
+		addColumn(&accLo, &accHi, k-4, accLo[k], accHi[k])
+	}
+
+	var t fieldElement448
+	var carry uint64
+	for i := 0; i < feLimbs; i++ {
+		sumLo, c := bits.Add64(accLo[i], carry, 0)
+		sumHi := accHi[i] + c
+		t[i] = sumLo & feLimbMask
+		carry = (sumHi << (64 - feLimbBits)) | (sumLo >> feLimbBits)
+	}
+	t[0] += carry
+	t[4] += carry
+
+	feCanonicalize(out, &t)
+}
+
+// addColumn adds the 128-bit value (hi:lo) into accumulator slot i,
+// propagating the 64-bit carry from the low word into the high word.
+func addColumn(accLo, accHi *[feLimbs + 3]uint64, i int, lo, hi uint64) {
+	var c uint64
+	accLo[i], c = bits.Add64(accLo[i], lo, 0)
+	accHi[i] += hi + c
+}
+
+// feInvert sets out = a^-1 (mod p), or out = 0 if a is 0, via Fermat's
+// little theorem (a^(p-2) == a^-1 (mod p)) and a straightforward
+// left-to-right square-and-multiply over p-2's bits.  feInvert runs
+// once per ScalarMult call, to convert the ladder's projective Z
+// coordinate back to affine, so the ~900 field operations this costs
+// are not worth shaving down with a shorter addition chain.
+func feInvert(out, a *fieldElement448) {
+	var exp fieldElement448
+	feSub(&exp, &pLimbs, &fieldElement448{2})
+
+	var buf [x448Bytes]byte
+	feToBytes(&buf, &exp)
+
+	var result fieldElement448
+	feOne(&result)
+	for i := x448Bytes*8 - 1; i >= 0; i-- {
+		feSquare(&result, &result)
+		byteIdx, bitIdx := i/8, uint(i%8)
+		if buf[byteIdx]&(1<<bitIdx) != 0 {
+			feMul(&result, &result, a)
+		}
+	}
+	feCopy(out, &result)
+}
@@ -0,0 +1,73 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2024 van-scott
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build amd64 && !purego
+
+package x448
+
+import "golang.org/x/sys/cpu"
+
+// hasBMI2ADX reports whether the CPU supports both the BMI2 and ADX
+// instruction set extensions, which feMul/feSquare below require to
+// take the feMulColumnsAMD64 fast path.  CPUs without both fall back
+// to the portable implementation.
+var hasBMI2ADX = cpu.X86.HasBMI2 && cpu.X86.HasADX
+
+// feMul sets out = a*b (mod p).  On a CPU with BMI2 and ADX,
+// feMulColumnsAMD64 computes the raw schoolbook columns using
+// MULX/ADCX/ADOX and feFoldColumns (field.go) does the same reduction
+// the portable path uses; MULX doesn't touch flags and the two
+// independent ADCX/ADOX carry chains let the columns of a row be
+// produced with more instruction-level parallelism than a
+// bits.Mul64/bits.Add64 loop allows, which measurably speeds up
+// feMul/feSquare over feMulGeneric/feSquareGeneric.
+func feMul(out, a, b *fieldElement448) {
+	if !hasBMI2ADX {
+		feMulGeneric(out, a, b)
+		return
+	}
+	var lo, hi [2*feLimbs - 1]uint64
+	feMulColumnsAMD64(&lo, &hi, a, b)
+	feFoldColumns(out, &lo, &hi)
+}
+
+// feSquare sets out = a*a (mod p).  See feMul; feMulColumnsAMD64 has no
+// dedicated squaring path, so this just calls feMul(out, a, a) the
+// same way feSquareGeneric calls feMulGeneric.
+func feSquare(out, a *fieldElement448) {
+	feMul(out, a, a)
+}
+
+// cswap conditionally swaps a and b in constant time when swap == 1,
+// leaving them unchanged when swap == 0, as used by the Montgomery
+// ladder to hide the scalar's bit pattern.  cswapAMD64 is a plain
+// constant-time word swap: it needs no BMI2/ADX support, so it runs
+// unconditionally on amd64 rather than dispatching on hasBMI2ADX.
+func cswap(a, b *fieldElement448, swap uint64) {
+	cswapAMD64(a, b, swap)
+}
+
+//go:noescape
+func cswapAMD64(a, b *fieldElement448, swap uint64)
+
+//go:noescape
+func feMulColumnsAMD64(lo, hi *[2*feLimbs - 1]uint64, a, b *fieldElement448)
@@ -0,0 +1,113 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2024 van-scott
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build amd64 && !purego
+
+package x448
+
+import (
+	"crypto/rand"
+	"testing"
+)
+
+// TestFieldOpsMatchGeneric fuzzes cswapAMD64 against cswapGeneric to
+// keep them bit-identical.  cswapAMD64 needs no CPU feature beyond
+// baseline amd64, so it always runs here; feMulColumnsAMD64 is covered
+// by TestFeMulColumnsAMD64MatchesGeneric and TestFeMulMatchesGeneric
+// below.
+func TestFieldOpsMatchGeneric(t *testing.T) {
+	for i := 0; i < 256; i++ {
+		var a, b, wantA, wantB fieldElement448
+		randomFieldElement(t, &a)
+		randomFieldElement(t, &b)
+		wantA, wantB = a, b
+
+		swap := uint64(i & 1)
+		cswapAMD64(&a, &b, swap)
+		cswapGeneric(&wantA, &wantB, swap)
+
+		if a != wantA || b != wantB {
+			t.Fatalf("cswap(%d): asm and generic diverged", i)
+		}
+	}
+}
+
+// TestFeMulColumnsAMD64MatchesGeneric fuzzes feMulColumnsAMD64's raw
+// schoolbook columns against feMulGeneric's, folding both down with
+// the same feFoldColumns so a divergence in the asm can't hide behind
+// the reduction.  It is skipped on CPUs without BMI2/ADX, since
+// feMulColumnsAMD64 requires both.
+func TestFeMulColumnsAMD64MatchesGeneric(t *testing.T) {
+	if !hasBMI2ADX {
+		t.Skip("CPU lacks BMI2/ADX")
+	}
+	for i := 0; i < 256; i++ {
+		var a, b fieldElement448
+		randomFieldElement(t, &a)
+		randomFieldElement(t, &b)
+
+		var gotOut, wantOut fieldElement448
+		var lo, hi [2*feLimbs - 1]uint64
+		feMulColumnsAMD64(&lo, &hi, &a, &b)
+		feFoldColumns(&gotOut, &lo, &hi)
+		feMulGeneric(&wantOut, &a, &b)
+
+		if gotOut != wantOut {
+			t.Fatalf("feMul(%d): asm and generic diverged: got %v want %v", i, gotOut, wantOut)
+		}
+	}
+}
+
+// TestFeMulMatchesGeneric fuzzes the feMul/feSquare entry points
+// (which dispatch to feMulColumnsAMD64 when hasBMI2ADX, and to the
+// portable path otherwise) against the portable implementation; it
+// runs whether or not this specific machine has BMI2/ADX.
+func TestFeMulMatchesGeneric(t *testing.T) {
+	for i := 0; i < 256; i++ {
+		var a, b fieldElement448
+		randomFieldElement(t, &a)
+		randomFieldElement(t, &b)
+
+		var gotMul, wantMul fieldElement448
+		feMul(&gotMul, &a, &b)
+		feMulGeneric(&wantMul, &a, &b)
+		if gotMul != wantMul {
+			t.Fatalf("feMul(%d): got %v want %v", i, gotMul, wantMul)
+		}
+
+		var gotSq, wantSq fieldElement448
+		feSquare(&gotSq, &a)
+		feSquareGeneric(&wantSq, &a)
+		if gotSq != wantSq {
+			t.Fatalf("feSquare(%d): got %v want %v", i, gotSq, wantSq)
+		}
+	}
+}
+
+func randomFieldElement(t *testing.T, fe *fieldElement448) {
+	t.Helper()
+	buf := make([]byte, 56)
+	if _, err := rand.Read(buf); err != nil {
+		t.Fatal(err)
+	}
+	feFromBytes(fe, buf)
+}
@@ -0,0 +1,42 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2024 van-scott
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build !amd64 || purego
+
+package x448
+
+// On architectures without an assembly backend (or when built with the
+// purego tag), feMul/feSquare/cswap are the portable implementations
+// directly; see field_amd64.go for the amd64 dispatch that picks
+// between these and the BMI2/ADX-accelerated versions.
+
+func feMul(out, a, b *fieldElement448) {
+	feMulGeneric(out, a, b)
+}
+
+func feSquare(out, a *fieldElement448) {
+	feSquareGeneric(out, a)
+}
+
+func cswap(a, b *fieldElement448, swap uint64) {
+	cswapGeneric(a, b, swap)
+}
@@ -0,0 +1,81 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2024 van-scott
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package hpke
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// AEAD is the small interface the KeySchedule's encryption context uses
+// to perform sealing and opening, letting callers plug in any AEAD
+// construction RFC 9180 allows without this package needing to know
+// about it ahead of time.
+type AEAD interface {
+	// ID reports the AEAD's aead_id, from RFC 9180 Section 7.3, which
+	// the KeySchedule binds into its suite_id.
+	ID() uint16
+
+	// KeySize and NonceSize report the AEAD's required key and nonce
+	// lengths, in bytes.
+	KeySize() int
+	NonceSize() int
+
+	// New constructs a cipher.AEAD bound to key, which is exactly
+	// KeySize() bytes long.
+	New(key []byte) (cipher.AEAD, error)
+}
+
+// AES128GCM is the AEAD implementing RFC 9180's aead_id 0x0001.
+var AES128GCM AEAD = aesGCM{keySize: 16}
+
+// ChaCha20Poly1305 is the AEAD implementing RFC 9180's aead_id 0x0003.
+var ChaCha20Poly1305 AEAD = chachaPoly1305{}
+
+type aesGCM struct {
+	keySize int
+}
+
+func (a aesGCM) ID() uint16     { return AEADAES128GCM }
+func (a aesGCM) KeySize() int   { return a.keySize }
+func (a aesGCM) NonceSize() int { return 12 }
+
+func (a aesGCM) New(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+type chachaPoly1305 struct{}
+
+func (chachaPoly1305) ID() uint16     { return AEADChaCha20Poly1305 }
+func (chachaPoly1305) KeySize() int   { return chacha20poly1305.KeySize }
+func (chachaPoly1305) NonceSize() int { return chacha20poly1305.NonceSize }
+
+func (chachaPoly1305) New(key []byte) (cipher.AEAD, error) {
+	return chacha20poly1305.New(key)
+}
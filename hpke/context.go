@@ -0,0 +1,204 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2024 van-scott
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package hpke
+
+import (
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+
+	"github.com/van-scott/x448"
+)
+
+var errPSKMismatch = errors.New("hpke: psk and psk_id must both be set, or both be empty")
+
+// Context is a bidirectional HPKE encryption context produced by
+// KeySchedule, Seal/Open on the sender and receiver side share the same
+// key and base nonce, XORed with an incrementing sequence number per
+// RFC 9180 Section 5.2.
+type Context struct {
+	aead           cipher.AEAD
+	baseNonce      []byte
+	seq            uint64
+	exporterSecret []byte
+	suiteID        []byte
+}
+
+// Seal encrypts and authenticates plaintext, authenticating aad as
+// additional data, and advances the context's sequence number.
+func (c *Context) Seal(aad, plaintext []byte) ([]byte, error) {
+	nonce, err := c.nextNonce()
+	if err != nil {
+		return nil, err
+	}
+	return c.aead.Seal(nil, nonce, plaintext, aad), nil
+}
+
+// Open decrypts and authenticates ciphertext, and advances the
+// context's sequence number.  It returns ErrOpen on authentication
+// failure.
+func (c *Context) Open(aad, ciphertext []byte) ([]byte, error) {
+	nonce, err := c.nextNonce()
+	if err != nil {
+		return nil, err
+	}
+	pt, err := c.aead.Open(nil, nonce, ciphertext, aad)
+	if err != nil {
+		return nil, ErrOpen
+	}
+	return pt, nil
+}
+
+// Export derives additional keying material from the context's
+// exporter secret, per RFC 9180 Section 5.3.
+func (c *Context) Export(exporterContext []byte, length int) []byte {
+	return labeledExpand(c.suiteID, c.exporterSecret, []byte("sec"), exporterContext, length)
+}
+
+func (c *Context) nextNonce() ([]byte, error) {
+	if c.seq == 1<<(8*uint(len(c.baseNonce)))-1 && len(c.baseNonce) < 8 {
+		return nil, errors.New("hpke: message limit reached")
+	}
+
+	nonce := make([]byte, len(c.baseNonce))
+	copy(nonce, c.baseNonce)
+
+	var seqBytes [8]byte
+	binary.BigEndian.PutUint64(seqBytes[:], c.seq)
+	off := len(nonce) - 8
+	for i := 0; i < 8 && off+i >= 0; i++ {
+		nonce[off+i] ^= seqBytes[i]
+	}
+
+	c.seq++
+	return nonce, nil
+}
+
+// keySchedule implements RFC 9180 Section 5.1's KeySchedule, shared by
+// all of the sender/receiver, PSK and non-PSK entry points below.
+func keySchedule(aead AEAD, mode byte, sharedSecret, info, psk, pskID []byte) (*Context, error) {
+	if (len(psk) == 0) != (len(pskID) == 0) {
+		return nil, errPSKMismatch
+	}
+
+	hpkeSuiteID := buildHPKESuiteID(KEMDHKEMX448HKDFSHA512, KDFHKDFSHA512, aead.ID())
+
+	pskIDHash := labeledExtract(hpkeSuiteID, nil, []byte("psk_id_hash"), pskID)
+	infoHash := labeledExtract(hpkeSuiteID, nil, []byte("info_hash"), info)
+
+	keyScheduleContext := append([]byte{mode}, pskIDHash...)
+	keyScheduleContext = append(keyScheduleContext, infoHash...)
+
+	secret := labeledExtract(hpkeSuiteID, sharedSecret, []byte("secret"), psk)
+
+	key := labeledExpand(hpkeSuiteID, secret, []byte("key"), keyScheduleContext, aead.KeySize())
+	baseNonce := labeledExpand(hpkeSuiteID, secret, []byte("base_nonce"), keyScheduleContext, aead.NonceSize())
+	exporterSecret := labeledExpand(hpkeSuiteID, secret, []byte("exp"), keyScheduleContext, NSecret)
+
+	a, err := aead.New(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Context{aead: a, baseNonce: baseNonce, exporterSecret: exporterSecret, suiteID: hpkeSuiteID}, nil
+}
+
+// SetupBaseS sets up the sender's encryption context in Base mode.
+func SetupBaseS(aead AEAD, pkR x448.PublicKey, ephemeral x448.PrivateKey, info []byte) (enc []byte, ctx *Context, err error) {
+	shared, enc, err := encapDeterministic(pkR, ephemeral)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx, err = keySchedule(aead, modeBase, shared, info, nil, nil)
+	return enc, ctx, err
+}
+
+// SetupBaseR sets up the receiver's encryption context in Base mode.
+func SetupBaseR(aead AEAD, enc []byte, skR x448.PrivateKey, info []byte) (*Context, error) {
+	shared, err := Decap(enc, skR)
+	if err != nil {
+		return nil, err
+	}
+	return keySchedule(aead, modeBase, shared, info, nil, nil)
+}
+
+// SetupPSKS sets up the sender's encryption context in PSK mode.
+func SetupPSKS(aead AEAD, pkR x448.PublicKey, ephemeral x448.PrivateKey, info, psk, pskID []byte) (enc []byte, ctx *Context, err error) {
+	shared, enc, err := encapDeterministic(pkR, ephemeral)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx, err = keySchedule(aead, modePSK, shared, info, psk, pskID)
+	return enc, ctx, err
+}
+
+// SetupPSKR sets up the receiver's encryption context in PSK mode.
+func SetupPSKR(aead AEAD, enc []byte, skR x448.PrivateKey, info, psk, pskID []byte) (*Context, error) {
+	shared, err := Decap(enc, skR)
+	if err != nil {
+		return nil, err
+	}
+	return keySchedule(aead, modePSK, shared, info, psk, pskID)
+}
+
+// SetupAuthS sets up the sender's encryption context in Auth mode,
+// authenticating the sender's static key pair (skS, pkS).
+func SetupAuthS(aead AEAD, pkR x448.PublicKey, ephemeral, skS x448.PrivateKey, info []byte) (enc []byte, ctx *Context, err error) {
+	shared, enc, err := AuthEncap(pkR, ephemeral, skS)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx, err = keySchedule(aead, modeAuth, shared, info, nil, nil)
+	return enc, ctx, err
+}
+
+// SetupAuthR sets up the receiver's encryption context in Auth mode.
+func SetupAuthR(aead AEAD, enc []byte, skR x448.PrivateKey, pkS x448.PublicKey, info []byte) (*Context, error) {
+	shared, err := AuthDecap(enc, skR, pkS)
+	if err != nil {
+		return nil, err
+	}
+	return keySchedule(aead, modeAuth, shared, info, nil, nil)
+}
+
+// Seal is a single-shot Base mode encryption helper: it sets up a
+// sender context, seals exactly one message, and discards the context.
+func Seal(aead AEAD, pkR x448.PublicKey, ephemeral x448.PrivateKey, info, aad, plaintext []byte) (enc, ciphertext []byte, err error) {
+	enc, ctx, err := SetupBaseS(aead, pkR, ephemeral, info)
+	if err != nil {
+		return nil, nil, err
+	}
+	ciphertext, err = ctx.Seal(aad, plaintext)
+	return enc, ciphertext, err
+}
+
+// Open is a single-shot Base mode decryption helper: it sets up a
+// receiver context, opens exactly one message, and discards the
+// context.
+func Open(aead AEAD, enc []byte, skR x448.PrivateKey, info, aad, ciphertext []byte) ([]byte, error) {
+	ctx, err := SetupBaseR(aead, enc, skR, info)
+	if err != nil {
+		return nil, err
+	}
+	return ctx.Open(aad, ciphertext)
+}
@@ -0,0 +1,249 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2024 van-scott
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package hpke implements the Hybrid Public Key Encryption scheme from
+// RFC 9180, specialized to the DHKEM(X448, HKDF-SHA512) KEM built on top
+// of this module's ScalarMult/ScalarBaseMult.  It is intended as a
+// drop-in KEM provider for protocols such as MLS, ECH, and age-style
+// encrypted payloads.
+package hpke
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"errors"
+
+	"github.com/van-scott/x448"
+	"golang.org/x/crypto/hkdf"
+)
+
+// KEM and AEAD identifiers, from RFC 9180 Section 7.
+const (
+	KEMDHKEMX448HKDFSHA512 uint16 = 0x0021
+
+	KDFHKDFSHA512 uint16 = 0x0003
+
+	AEADAES128GCM        uint16 = 0x0001
+	AEADChaCha20Poly1305 uint16 = 0x0003
+)
+
+// Mode identifiers, from RFC 9180 Section 5.
+const (
+	modeBase    byte = 0x00
+	modePSK     byte = 0x01
+	modeAuth    byte = 0x02
+	modeAuthPSK byte = 0x03
+)
+
+const (
+	// NSecret is Nh, the output size in bytes of HKDF-SHA512.
+	NSecret = 64
+
+	// NEnc and NPk are the size, in bytes, of a DHKEM(X448, ...)
+	// encapsulated key / serialized public key.
+	NEnc = 56
+	NPk  = 56
+	NSk  = 56
+)
+
+// kemSuiteID is RFC 9180 Section 4.1's suite_id for the KEM-level
+// LabeledExtract/LabeledExpand calls (DeriveKeyPair and
+// extractAndExpand below). It depends only on the KEM, not the KDF or
+// AEAD in use.
+var kemSuiteID = buildKemSuiteID(KEMDHKEMX448HKDFSHA512)
+
+func buildKemSuiteID(kemID uint16) []byte {
+	return []byte{'K', 'E', 'M', byte(kemID >> 8), byte(kemID)}
+}
+
+// buildHPKESuiteID returns RFC 9180 Section 5.1's suite_id for the
+// KeySchedule-level LabeledExtract/LabeledExpand calls in
+// context.go, which unlike the KEM's suite_id also binds the KDF and
+// AEAD identifiers.
+func buildHPKESuiteID(kemID, kdfID, aeadID uint16) []byte {
+	return []byte{
+		'H', 'P', 'K', 'E',
+		byte(kemID >> 8), byte(kemID),
+		byte(kdfID >> 8), byte(kdfID),
+		byte(aeadID >> 8), byte(aeadID),
+	}
+}
+
+// ErrLowOrderPoint is returned when a DH computation during
+// encapsulation or decapsulation produces the all-zero output, which
+// happens when the peer's public key is a point of small order.
+var ErrLowOrderPoint = errors.New("hpke: low order point")
+
+// ErrOpen is returned when AEAD decryption fails (bad key, tag, or
+// associated data).
+var ErrOpen = errors.New("hpke: message authentication failed")
+
+// labeledExtract implements RFC 9180 Section 4's LabeledExtract, using
+// the "HPKE-v1" version label and the caller-supplied suite_id (which
+// differs between the KEM layer and the outer KeySchedule, per
+// Sections 4.1 and 5.1 respectively).
+func labeledExtract(suiteID, salt, label, ikm []byte) []byte {
+	labeledIKM := make([]byte, 0, 7+len(suiteID)+len(label)+len(ikm))
+	labeledIKM = append(labeledIKM, "HPKE-v1"...)
+	labeledIKM = append(labeledIKM, suiteID...)
+	labeledIKM = append(labeledIKM, label...)
+	labeledIKM = append(labeledIKM, ikm...)
+	return hkdf.Extract(sha512.New, labeledIKM, salt)
+}
+
+// labeledExpand implements RFC 9180 Section 4's LabeledExpand.
+func labeledExpand(suiteID, prk, label, info []byte, length int) []byte {
+	lengthPrefixed := make([]byte, 0, 2+7+len(suiteID)+len(label)+len(info))
+	lengthPrefixed = append(lengthPrefixed, byte(length>>8), byte(length))
+	lengthPrefixed = append(lengthPrefixed, "HPKE-v1"...)
+	lengthPrefixed = append(lengthPrefixed, suiteID...)
+	lengthPrefixed = append(lengthPrefixed, label...)
+	lengthPrefixed = append(lengthPrefixed, info...)
+
+	out := make([]byte, length)
+	r := hkdf.Expand(sha512.New, prk, lengthPrefixed)
+	if _, err := r.Read(out); err != nil {
+		panic("hpke: hkdf.Expand: " + err.Error())
+	}
+	return out
+}
+
+// DeriveKeyPair deterministically derives an X448 key pair from ikm, as
+// specified by RFC 9180 Section 7.1.3's DeriveKeyPair for DHKEM(X448, *).
+// Unlike the NIST curve KEMs, the X25519/X448 DeriveKeyPair has no
+// rejection loop or counter: sk is a single LabeledExpand of dkp_prk
+// under the "sk" label, and x448.PrivateKey applies RFC 7748 clamping
+// internally whenever the key is used, so DeriveKeyPair must not clamp
+// sk itself.
+func DeriveKeyPair(ikm []byte) (x448.PublicKey, x448.PrivateKey, error) {
+	dkpPRK := labeledExtract(kemSuiteID, nil, []byte("dkp_prk"), ikm)
+	sk := labeledExpand(kemSuiteID, dkpPRK, []byte("sk"), nil, NSk)
+
+	var priv x448.PrivateKey
+	copy(priv[:], sk)
+	return priv.Public(), priv, nil
+}
+
+// Encap generates a fresh random ephemeral key pair and uses it to
+// establish a shared secret with pkR, returning the secret and its
+// encapsulation (the ephemeral public key).  Callers that need to
+// supply their own ephemeral key (for example to reproduce RFC 9180's
+// test vectors) should use encapDeterministic instead.
+func Encap(pkR x448.PublicKey) (shared, enc []byte, err error) {
+	ephemeral, err := x448.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	return encapDeterministic(pkR, ephemeral)
+}
+
+// encapDeterministic is Encap with the ephemeral key pair supplied by
+// the caller rather than generated, so that callers who already hold
+// an ephemeral key (SetupBaseS/Seal and this package's RFC 9180 vector
+// tests) don't generate one twice.
+func encapDeterministic(pkR x448.PublicKey, ephemeral x448.PrivateKey) (shared, enc []byte, err error) {
+	pkE := ephemeral.Public()
+	dh, err := ephemeral.SharedSecret(pkR)
+	if err != nil {
+		return nil, nil, ErrLowOrderPoint
+	}
+
+	encBytes, _ := pkE.MarshalBinary()
+	pkRBytes, _ := pkR.MarshalBinary()
+	kemContext := append(append([]byte{}, encBytes...), pkRBytes...)
+
+	return extractAndExpand(dh, kemContext), encBytes, nil
+}
+
+// Decap recovers the shared secret established by Encap, given the
+// encapsulation enc and the recipient's private key skR.
+func Decap(enc []byte, skR x448.PrivateKey) (shared []byte, err error) {
+	var pkE x448.PublicKey
+	if err := pkE.UnmarshalBinary(enc); err != nil {
+		return nil, err
+	}
+
+	dh, err := skR.SharedSecret(pkE)
+	if err != nil {
+		return nil, ErrLowOrderPoint
+	}
+
+	pkR := skR.Public()
+	pkRBytes, _ := pkR.MarshalBinary()
+	kemContext := append(append([]byte{}, enc...), pkRBytes...)
+
+	return extractAndExpand(dh, kemContext), nil
+}
+
+// AuthEncap is the Auth mode variant of Encap, additionally binding the
+// sender's static key pair (skS, pkS) into the shared secret so the
+// recipient can authenticate the sender.
+func AuthEncap(pkR x448.PublicKey, ephemeral, skS x448.PrivateKey) (shared, enc []byte, err error) {
+	pkE := ephemeral.Public()
+	dh1, err := ephemeral.SharedSecret(pkR)
+	if err != nil {
+		return nil, nil, ErrLowOrderPoint
+	}
+	dh2, err := skS.SharedSecret(pkR)
+	if err != nil {
+		return nil, nil, ErrLowOrderPoint
+	}
+
+	encBytes, _ := pkE.MarshalBinary()
+	pkRBytes, _ := pkR.MarshalBinary()
+	pkS := skS.Public()
+	pkSBytes, _ := pkS.MarshalBinary()
+	kemContext := append(append(append([]byte{}, encBytes...), pkRBytes...), pkSBytes...)
+
+	return extractAndExpand(append(dh1, dh2...), kemContext), encBytes, nil
+}
+
+// AuthDecap is the Auth mode variant of Decap.
+func AuthDecap(enc []byte, skR x448.PrivateKey, pkS x448.PublicKey) (shared []byte, err error) {
+	var pkE x448.PublicKey
+	if err := pkE.UnmarshalBinary(enc); err != nil {
+		return nil, err
+	}
+
+	dh1, err := skR.SharedSecret(pkE)
+	if err != nil {
+		return nil, ErrLowOrderPoint
+	}
+	dh2, err := skR.SharedSecret(pkS)
+	if err != nil {
+		return nil, ErrLowOrderPoint
+	}
+
+	pkR := skR.Public()
+	pkRBytes, _ := pkR.MarshalBinary()
+	pkSBytes, _ := pkS.MarshalBinary()
+	kemContext := append(append(append([]byte{}, enc...), pkRBytes...), pkSBytes...)
+
+	return extractAndExpand(append(dh1, dh2...), kemContext), nil
+}
+
+// extractAndExpand implements RFC 9180 Section 4.1's ExtractAndExpand
+// for the DHKEM(X448, HKDF-SHA512) instantiation.
+func extractAndExpand(dh, kemContext []byte) []byte {
+	eaePRK := labeledExtract(kemSuiteID, nil, []byte("eae_prk"), dh)
+	return labeledExpand(kemSuiteID, eaePRK, []byte("shared_secret"), kemContext, NSecret)
+}
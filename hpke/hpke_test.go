@@ -0,0 +1,273 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2024 van-scott
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package hpke
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/van-scott/x448"
+)
+
+// rfc9180Vectors are RFC 9180 Appendix A's Base mode test vectors for
+// DHKEM(X448, HKDF-SHA512) with HKDF-SHA512, one per AEAD.
+var rfc9180Vectors = []struct {
+	aead                   AEAD
+	skEm, skRm, pkRm, info string
+	enc, sharedSecret      string
+	exportedValue          string
+	aad, pt, ct            string
+}{
+	{
+		aead: AES128GCM,
+		skEm: "f283abf2888eda7b0db0f1bdbfc7f4fad526041bdc6cc8a3c3a6961c926bd2749e9b243c31a76f830f99aa2ac2a07a3391b7c94c18167838",
+		skRm: "b59d33ccc522678b38224e14f46197b9f3d54d23ee6f3d93b971d6901863038b6c2d0a1ae85cb0b0f57e6f738a571552a1d4d2a69321c4f4",
+		pkRm: "66614788404568d059741319ed47991d42a545a56c2ffc51738460b4338342aa4ee6d48a4eaf6b4490f86185cd17f443925964f3dfbf03f5",
+		info: "4f6465206f6e2061204772656369616e2055726e",
+		enc:  "0aea40233b445e66f997ce3efe0584e4609b9f4ea217074aed73fe4b36aecaaf55897530e55bea8cd18360ca4dbcac0966cb3deb8f5aad85",
+		sharedSecret: "377c79f666ff19c3bdab01902bb4321d6ceee377fab181e7862a4f4b08bc0812b0" +
+			"18e08cfcc94914b5c9b4139fc0b5d0078dc96f9c901634e4c45f1139ff92e1",
+		exportedValue: "ab89681d22a7940e702374bbdb782d5cc911feea6f1c27f8cfba15367282fca2",
+		aad:           "436f756e742d30",
+		pt:            "4265617574792069732074727574682c20747275746820626561757479",
+		ct:            "ede82da58afd1cd19165542875456530df4c92ede352d427bccabf08884a22b81e3da8e0055c637d549fb11f40",
+	},
+	{
+		aead: ChaCha20Poly1305,
+		skEm: "fa7562b37eef0c60126a0cac505c9a8854223794ee5c195f44ede823f9a74c41697c8927d056f8920ba7e021bde91b749751a1253a964aa6",
+		skRm: "c2f51845154d6bb6917e44ef0fa0a1fbf1d80f61d199486e75295e8a7e50432d548a7f8040953826c4f1bce79e433dedb4469391c3cc98a1",
+		pkRm: "f2fdb31a7829a6d2d78b9d8b670397457c92cb2417af37dbe0c1c12a9547e4eda9fde09fc3fe0f359bb7b4151e8a6fb592530af71d9dc0b5",
+		info: "4f6465206f6e2061204772656369616e2055726e",
+		enc:  "3d4f6aa08c635205bcd96a0791695d08638714474b4d2c0132b69e25cdb826e1a2a84bc0c40c4fc75f52051b034e0afa82b8457e28794f92",
+		sharedSecret: "cc20a83a9af44bc5a03a53f06beb01af474d5a85dd3c4f2082197ccdfe32a275" +
+			"996e497433e58460726459a1b40e31e6141e1fb605fb8ae0580b90bd7398f318",
+		exportedValue: "d13d9f30a9de3369f25b8de6a733d9c5b68a79b148a662a44cb84e9296419ed6",
+		aad:           "436f756e742d30",
+		pt:            "4265617574792069732074727574682c20747275746820626561757479",
+		ct:            "4df124bd68d45b84dd5b82146597cdab8b56ab618166f814c2fe98ce35f43b09917283a58810aac71e852bff0a",
+	},
+}
+
+// TestRFC9180Vectors checks the Base mode setup, sealed ciphertext, and
+// exported value against RFC 9180 Appendix A's DHKEM(X448, HKDF-SHA512)
+// test vectors, one per supported AEAD.
+func TestRFC9180Vectors(t *testing.T) {
+	for i, v := range rfc9180Vectors {
+		skEm := mustHexDecode(t, v.skEm)
+		skRm := mustHexDecode(t, v.skRm)
+		pkRm := mustHexDecode(t, v.pkRm)
+		info := mustHexDecode(t, v.info)
+		wantEnc := mustHexDecode(t, v.enc)
+		wantSharedSecret := mustHexDecode(t, v.sharedSecret)
+		wantExportedValue := mustHexDecode(t, v.exportedValue)
+		aad := mustHexDecode(t, v.aad)
+		pt := mustHexDecode(t, v.pt)
+		wantCt := mustHexDecode(t, v.ct)
+
+		var ephemeral, skR x448.PrivateKey
+		if err := ephemeral.UnmarshalBinary(skEm); err != nil {
+			t.Fatalf("test %d: %v", i, err)
+		}
+		if err := skR.UnmarshalBinary(skRm); err != nil {
+			t.Fatalf("test %d: %v", i, err)
+		}
+		var pkR x448.PublicKey
+		if err := pkR.UnmarshalBinary(pkRm); err != nil {
+			t.Fatalf("test %d: %v", i, err)
+		}
+
+		shared, enc, err := encapDeterministic(pkR, ephemeral)
+		if err != nil {
+			t.Fatalf("test %d: encapDeterministic: %v", i, err)
+		}
+		if !bytes.Equal(enc, wantEnc) {
+			t.Errorf("test %d: enc mismatch: got %x want %x", i, enc, wantEnc)
+		}
+		if !bytes.Equal(shared, wantSharedSecret) {
+			t.Errorf("test %d: shared secret mismatch: got %x want %x", i, shared, wantSharedSecret)
+		}
+
+		_, ctxS, err := SetupBaseS(v.aead, pkR, ephemeral, info)
+		if err != nil {
+			t.Fatalf("test %d: SetupBaseS: %v", i, err)
+		}
+		ctxR, err := SetupBaseR(v.aead, enc, skR, info)
+		if err != nil {
+			t.Fatalf("test %d: SetupBaseR: %v", i, err)
+		}
+
+		ct, err := ctxS.Seal(aad, pt)
+		if err != nil {
+			t.Fatalf("test %d: Seal: %v", i, err)
+		}
+		if !bytes.Equal(ct, wantCt) {
+			t.Errorf("test %d: ciphertext mismatch: got %x want %x", i, ct, wantCt)
+		}
+
+		got, err := ctxR.Open(aad, wantCt)
+		if err != nil {
+			t.Fatalf("test %d: Open: %v", i, err)
+		}
+		if !bytes.Equal(got, pt) {
+			t.Errorf("test %d: opened plaintext mismatch: got %x want %x", i, got, pt)
+		}
+
+		exported := ctxS.Export(nil, len(wantExportedValue))
+		if !bytes.Equal(exported, wantExportedValue) {
+			t.Errorf("test %d: exported value mismatch: got %x want %x", i, exported, wantExportedValue)
+		}
+	}
+}
+
+func mustHexDecode(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatalf("bad hex %q: %v", s, err)
+	}
+	return b
+}
+
+// TestEncapDecapRoundTrip checks the public, randomized Encap against
+// Decap: Encap generates its own ephemeral key rather than requiring
+// the caller to supply one, unlike encapDeterministic.
+func TestEncapDecapRoundTrip(t *testing.T) {
+	privR, err := x448.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubR := privR.Public()
+
+	shared, enc, err := Encap(pubR)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Decap(enc, privR)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, shared) {
+		t.Error("Decap did not recover the shared secret established by Encap")
+	}
+}
+
+func TestSealOpenRoundTrip(t *testing.T) {
+	for _, aead := range []AEAD{AES128GCM, ChaCha20Poly1305} {
+		privR, err := x448.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pubR := privR.Public()
+
+		ephemeral, err := x448.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		info := []byte("test info")
+		aad := []byte("test aad")
+		plaintext := []byte("hello, hpke")
+
+		enc, ciphertext, err := Seal(aead, pubR, ephemeral, info, aad, plaintext)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := Open(aead, enc, privR, info, aad, ciphertext)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, plaintext) {
+			t.Error("decrypted plaintext does not match original")
+		}
+	}
+}
+
+func TestOpenRejectsTamperedCiphertext(t *testing.T) {
+	privR, err := x448.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubR := privR.Public()
+
+	ephemeral, err := x448.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	enc, ciphertext, err := Seal(AES128GCM, pubR, ephemeral, nil, nil, []byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext[0] ^= 0xff
+
+	if _, err := Open(AES128GCM, enc, privR, nil, nil, ciphertext); err != ErrOpen {
+		t.Errorf("got error %v, want ErrOpen", err)
+	}
+}
+
+func TestDeriveKeyPairDeterministic(t *testing.T) {
+	ikm := []byte("test ikm, at least 56 bytes long to satisfy x448........")
+
+	pub1, priv1, err := DeriveKeyPair(ikm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub2, priv2, err := DeriveKeyPair(ikm)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !priv1.Equal(&priv2) {
+		t.Error("DeriveKeyPair is not deterministic")
+	}
+	if !pub1.Equal(&pub2) {
+		t.Error("DeriveKeyPair is not deterministic")
+	}
+}
+
+// TestDeriveKeyPairMatchesRFC9180Formula pins DeriveKeyPair to RFC 9180
+// Section 7.1.3's DeriveKeyPair for DHKEM(X25519, *)/DHKEM(X448, *):
+// sk = LabeledExpand(dkp_prk, "sk", "", Nsk), with no counter/rejection
+// loop and no clamping of sk itself (x448.PrivateKey clamps internally
+// whenever it is used). A previous version of this function instead
+// pasted in the NIST curve KEM template -- a "candidate" label, an
+// I2OSP(counter, 1) info string, and an X448 clamp applied to sk -- which
+// does not reproduce RFC 9180's DHKEM(X448, *) key derivation.
+func TestDeriveKeyPairMatchesRFC9180Formula(t *testing.T) {
+	ikm := []byte("another sample ikm for DeriveKeyPair regression testing")
+
+	dkpPRK := labeledExtract(kemSuiteID, nil, []byte("dkp_prk"), ikm)
+	wantSK := labeledExpand(kemSuiteID, dkpPRK, []byte("sk"), nil, NSk)
+
+	_, priv, err := DeriveKeyPair(ikm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(priv[:], wantSK) {
+		t.Errorf("DeriveKeyPair sk = %x, want %x", priv[:], wantSK)
+	}
+}
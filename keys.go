@@ -0,0 +1,152 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2024 van-scott
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package x448
+
+import (
+	"crypto/subtle"
+	"errors"
+	"io"
+)
+
+// ErrLowOrderPoint is returned by (PrivateKey).SharedSecret when the
+// peer's public key is a point of small order, causing the computed
+// shared secret to be the all-zero output.  Callers that do not check
+// for this are vulnerable to small-subgroup attacks; see
+// https://cr.yp.to/ecdh.html#validate.
+var ErrLowOrderPoint = errors.New("x448: peer's public key is a low order point")
+
+// ErrInvalidKeySize is returned by (PrivateKey).UnmarshalBinary and
+// (PublicKey).UnmarshalBinary when the supplied data is not exactly
+// x448Bytes long.
+var ErrInvalidKeySize = errors.New("x448: invalid key size")
+
+// PublicKey is an X448 public key.  PublicKey satisfies crypto.PublicKey.
+type PublicKey [x448Bytes]byte
+
+// Equal reports whether pub and other represent the same public key,
+// using a constant-time comparison.
+func (pub *PublicKey) Equal(other *PublicKey) bool {
+	return subtle.ConstantTimeCompare(pub[:], other[:]) == 1
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (pub *PublicKey) MarshalBinary() ([]byte, error) {
+	out := make([]byte, x448Bytes)
+	copy(out, pub[:])
+	return out, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (pub *PublicKey) UnmarshalBinary(data []byte) error {
+	if len(data) != x448Bytes {
+		return ErrInvalidKeySize
+	}
+	copy(pub[:], data)
+	return nil
+}
+
+// PrivateKey is an X448 private key (scalar).  It is stored unclamped;
+// clamping is applied internally whenever the key is used.  PrivateKey
+// satisfies crypto.PrivateKey.
+type PrivateKey [x448Bytes]byte
+
+// GenerateKey generates a new PrivateKey using entropy from rand.
+func GenerateKey(rand io.Reader) (PrivateKey, error) {
+	var priv PrivateKey
+	if _, err := io.ReadFull(rand, priv[:]); err != nil {
+		return PrivateKey{}, err
+	}
+	return priv, nil
+}
+
+// Public returns the PublicKey corresponding to priv.
+func (priv *PrivateKey) Public() PublicKey {
+	var clamped [x448Bytes]byte
+	copy(clamped[:], priv[:])
+	clampPrivateKey(&clamped)
+
+	var pub PublicKey
+	ScalarBaseMult((*[x448Bytes]byte)(&pub), &clamped)
+	return pub
+}
+
+// SharedSecret computes the X448 shared secret between priv and peer.
+// It returns ErrLowOrderPoint if peer is (or reduces to) a point of
+// small order, since the resulting shared secret would otherwise be the
+// well-known all-zero output.  Callers implementing protocols such as
+// TLS 1.3 or Noise MUST check for this error and abort the handshake.
+func (priv *PrivateKey) SharedSecret(peer PublicKey) ([]byte, error) {
+	var clamped [x448Bytes]byte
+	copy(clamped[:], priv[:])
+	clampPrivateKey(&clamped)
+
+	var shared [x448Bytes]byte
+	peerBytes := [x448Bytes]byte(peer)
+	ScalarMult(&shared, &clamped, &peerBytes)
+
+	if isAllZero(shared[:]) {
+		return nil, ErrLowOrderPoint
+	}
+
+	out := make([]byte, x448Bytes)
+	copy(out, shared[:])
+	return out, nil
+}
+
+// Equal reports whether priv and other represent the same private key,
+// using a constant-time comparison.
+func (priv *PrivateKey) Equal(other *PrivateKey) bool {
+	return subtle.ConstantTimeCompare(priv[:], other[:]) == 1
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (priv *PrivateKey) MarshalBinary() ([]byte, error) {
+	out := make([]byte, x448Bytes)
+	copy(out, priv[:])
+	return out, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (priv *PrivateKey) UnmarshalBinary(data []byte) error {
+	if len(data) != x448Bytes {
+		return ErrInvalidKeySize
+	}
+	copy(priv[:], data)
+	return nil
+}
+
+// clampPrivateKey applies the clamping required by RFC 7748 Section 5:
+// the low two bits of the first byte are cleared, and the high bit of
+// the last byte is set.
+func clampPrivateKey(k *[x448Bytes]byte) {
+	k[0] &= 0xfc
+	k[x448Bytes-1] |= 0x80
+}
+
+func isAllZero(b []byte) bool {
+	var acc byte
+	for _, v := range b {
+		acc |= v
+	}
+	return acc == 0
+}
@@ -0,0 +1,112 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2024 van-scott
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package x448
+
+import (
+	"crypto/rand"
+	"testing"
+)
+
+func TestPrivateKeySharedSecret(t *testing.T) {
+	alice, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bob, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	aliceShared, err := alice.SharedSecret(bob.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	bobShared, err := bob.SharedSecret(alice.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(aliceShared) != string(bobShared) {
+		t.Error("Alice and Bob's shared secrets do not match")
+	}
+}
+
+func TestPrivateKeySharedSecretLowOrderPoint(t *testing.T) {
+	alice, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The all-zero public key is a point of order 1 (2 in the wire
+	// encoding, once the birational map is applied), and must be
+	// rejected rather than yielding an all-zero shared secret.
+	var lowOrder PublicKey
+	if _, err := alice.SharedSecret(lowOrder); err != ErrLowOrderPoint {
+		t.Errorf("got error %v, want ErrLowOrderPoint", err)
+	}
+}
+
+func TestPublicKeyEqual(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, b := priv.Public(), priv.Public()
+	if !a.Equal(&b) {
+		t.Error("identical public keys compared unequal")
+	}
+
+	other, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := other.Public()
+	if a.Equal(&c) {
+		t.Error("distinct public keys compared equal")
+	}
+}
+
+func TestPublicKeyMarshalRoundTrip(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub := priv.Public()
+
+	data, err := pub.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded PublicKey
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if !pub.Equal(&decoded) {
+		t.Error("round-tripped public key does not match original")
+	}
+
+	if err := decoded.UnmarshalBinary(data[:len(data)-1]); err != ErrInvalidKeySize {
+		t.Errorf("got error %v, want ErrInvalidKeySize", err)
+	}
+}
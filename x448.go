@@ -0,0 +1,154 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2024 van-scott
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package x448 implements the X448 Diffie-Hellman function as specified
+// in RFC 7748, operating on Curve448.
+//
+// The field arithmetic and Montgomery ladder in this file and field.go
+// are the most security-critical code in this module: a wrong constant
+// or a non-constant-time branch here is undetectable by round-trip
+// testing against this package's own output, since both sides of a
+// test would derive the same wrong answer. ed448's sibling curve
+// equation and constants were wrong until an out-of-range panic caught
+// it; treat any change here as warranting the same scrutiny, including
+// cross-checking against RFC 7748's test vectors and an independent
+// implementation.
+//
+// On amd64 CPUs with BMI2 and ADX, feMul/feSquare (field_amd64.go) use
+// a MULX/ADCX/ADOX-accelerated multiply instead of field.go's portable
+// feMulGeneric/feSquareGeneric; see hasBMI2ADX and feMulColumnsAMD64.
+package x448
+
+import "math/bits"
+
+// x448Bytes is the size, in bytes, of a scalar, a u-coordinate, and the
+// output of ScalarMult/ScalarBaseMult.
+const x448Bytes = 56
+
+// a24 is (A-2)/4 for Curve448's Montgomery coefficient A = 156326, as
+// used by the ladder step below.
+const a24 = 39081
+
+// basePoint is the RFC 7748 Section 4.2 base point u-coordinate (5),
+// little-endian encoded.
+var basePoint = [x448Bytes]byte{5}
+
+// ScalarMult sets dst to the result of the X448 scalar multiplication
+// of scalar and point, as specified by RFC 7748 Section 5.  scalar is
+// clamped internally per Section 5's decodeScalar448.
+func ScalarMult(dst, scalar, point *[x448Bytes]byte) {
+	var x, z fieldElement448
+	ladderProject(&x, &z, scalar, point)
+
+	var zInv, out fieldElement448
+	feInvert(&zInv, &z)
+	feMul(&out, &x, &zInv)
+	feToBytes(dst, &out)
+}
+
+// ScalarBaseMult sets dst to the result of the X448 scalar
+// multiplication of scalar and the base point u=5.
+func ScalarBaseMult(dst, scalar *[x448Bytes]byte) {
+	ScalarMult(dst, scalar, &basePoint)
+}
+
+// ladderProject runs the X448 Montgomery ladder (RFC 7748 Section 5)
+// and returns the resulting point's projective (X:Z) coordinates
+// without performing the final inversion, so that batch.go's
+// ScalarMultBatch can amortize it with Montgomery's trick across many
+// ladders.  Every ladder step runs in constant time with respect to
+// scalar, regardless of its bit pattern.
+func ladderProject(outX, outZ *fieldElement448, scalar, point *[x448Bytes]byte) {
+	var k [x448Bytes]byte
+	copy(k[:], scalar[:])
+	clampPrivateKey(&k)
+
+	// RFC 7748 Section 5 requires accepting non-canonical u-coordinate
+	// encodings (value >= p) and treating them as already reduced.
+	var x1 fieldElement448
+	feFromBytes(&x1, point[:])
+	feCanonicalize(&x1, &x1)
+
+	var x2, z2, x3, z3 fieldElement448
+	feOne(&x2)
+	feCopy(&x3, &x1)
+	feOne(&z3)
+
+	var swap uint64
+	for t := x448Bytes*8 - 1; t >= 0; t-- {
+		byteIdx, bitIdx := t/8, uint(t%8)
+		kt := uint64(k[byteIdx]>>bitIdx) & 1
+
+		swap ^= kt
+		cswap(&x2, &x3, swap)
+		cswap(&z2, &z3, swap)
+		swap = kt
+
+		var a, aa, b, bb, e, c, d, da, cb fieldElement448
+		feAdd(&a, &x2, &z2)
+		feSquare(&aa, &a)
+		feSub(&b, &x2, &z2)
+		feSquare(&bb, &b)
+		feSub(&e, &aa, &bb)
+		feAdd(&c, &x3, &z3)
+		feSub(&d, &x3, &z3)
+		feMul(&da, &d, &a)
+		feMul(&cb, &c, &b)
+
+		var sum, diff fieldElement448
+		feAdd(&sum, &da, &cb)
+		feSquare(&x3, &sum)
+		feSub(&diff, &da, &cb)
+		feSquare(&diff, &diff)
+		feMul(&z3, &x1, &diff)
+
+		feMul(&x2, &aa, &bb)
+		var aE fieldElement448
+		feMulSmall(&aE, &e, a24)
+		feAdd(&aE, &aE, &aa)
+		feMul(&z2, &e, &aE)
+	}
+	cswap(&x2, &x3, swap)
+	cswap(&z2, &z3, swap)
+
+	*outX, *outZ = x2, z2
+}
+
+// feMulSmall sets out = a*n (mod p) for a small constant n (a24 == 39081
+// is this package's only caller).  a's limbs are always canonical
+// (< 2^56) on entry, so a[i]*n fits in 72 bits; bits.Mul64 captures the
+// full double-word product before the usual carry propagation and
+// reduction.
+func feMulSmall(out, a *fieldElement448, n uint64) {
+	var t fieldElement448
+	var carry uint64
+	for i := 0; i < feLimbs; i++ {
+		hi, lo := bits.Mul64(a[i], n)
+		lo, c := bits.Add64(lo, carry, 0)
+		hi += c
+		t[i] = lo & feLimbMask
+		carry = (hi << (64 - feLimbBits)) | (lo >> feLimbBits)
+	}
+	t[0] += carry
+	t[4] += carry
+	feCanonicalize(out, &t)
+}